@@ -0,0 +1,30 @@
+// +build linux
+
+// Package cpu selects the cpu cgroup controller implementation that
+// matches whichever hierarchy is actually mounted, so callers don't have
+// to duplicate the fs vs fs2 decision themselves.
+package cpu
+
+import (
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs2"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// Controller is satisfied by both fs.CpuGroup (v1) and fs2.CpuGroup (v2).
+type Controller interface {
+	Name() string
+	Set(path string, cgroup *configs.Cgroup) error
+	GetStats(path string, stats *cgroups.Stats) error
+}
+
+// New returns the cpu cgroup controller for whichever hierarchy is
+// mounted: an fs2.CpuGroup under cgroup v2 (unified mode), or an
+// fs.CpuGroup otherwise.
+func New() Controller {
+	if cgroups.IsCgroup2UnifiedMode() {
+		return &fs2.CpuGroup{}
+	}
+	return &fs.CpuGroup{}
+}
@@ -0,0 +1,15 @@
+// +build linux
+
+package cpu
+
+import "testing"
+
+func TestNewReturnsAController(t *testing.T) {
+	c := New()
+	if c == nil {
+		t.Fatal("New() returned nil")
+	}
+	if c.Name() != "cpu" {
+		t.Fatalf("Name() = %q, want %q", c.Name(), "cpu")
+	}
+}
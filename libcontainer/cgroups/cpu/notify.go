@@ -0,0 +1,93 @@
+// +build linux
+
+package cpu
+
+import (
+	"context"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// CpuThrottleEvent reports how much a cgroup was throttled during one
+// sampling window of Notify.
+type CpuThrottleEvent struct {
+	// ThrottledPeriods is the increase in nr_throttled since the last tick.
+	ThrottledPeriods uint64
+	// Periods is the increase in nr_periods since the last tick.
+	Periods uint64
+	// ThrottledTime is the increase in cpu.stat's throttled time (in
+	// nanoseconds) since the last tick.
+	ThrottledTime uint64
+}
+
+// defaultNotifyInterval is how often Notify samples cpu.stat.
+const defaultNotifyInterval = 1 * time.Second
+
+// nextThrottleEvent computes the delta between two samples and reports
+// whether it's big enough to emit, split out from Notify so the threshold
+// logic can be unit tested without goroutines or timers.
+func nextThrottleEvent(prev, cur cgroups.Stats, threshold uint64) (CpuThrottleEvent, bool) {
+	deltaThrottledTime := cur.CpuStats.ThrottlingData.ThrottledTime - prev.CpuStats.ThrottlingData.ThrottledTime
+	if deltaThrottledTime <= threshold {
+		return CpuThrottleEvent{}, false
+	}
+	return CpuThrottleEvent{
+		ThrottledPeriods: cur.CpuStats.ThrottlingData.ThrottledPeriods - prev.CpuStats.ThrottlingData.ThrottledPeriods,
+		Periods:          cur.CpuStats.ThrottlingData.Periods - prev.CpuStats.ThrottlingData.Periods,
+		ThrottledTime:    deltaThrottledTime,
+	}, true
+}
+
+// Notify periodically samples cpu.stat at path via ctrl (either an
+// fs.CpuGroup or an fs2.CpuGroup, so this works the same under cgroup v1
+// and v2) and emits a CpuThrottleEvent on the returned channel whenever the
+// throttled time accrued since the previous sample exceeds threshold (in
+// nanoseconds). Sampling stops, and the channel is closed, when ctx is
+// done.
+//
+// This is meant to be wired into libcontainer.Container the same way
+// notifyOnOOM exposes the memory cgroup's OOM control as a channel: a
+// Container.NotifyCPUThrottle would call through to here for its cpu
+// cgroup path, giving callers a CPU-pressure signal alongside the
+// existing OOM one.
+func Notify(ctx context.Context, ctrl Controller, path string, threshold uint64) (<-chan CpuThrottleEvent, error) {
+	ch := make(chan CpuThrottleEvent)
+
+	var prev cgroups.Stats
+	if err := ctrl.GetStats(path, &prev); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(defaultNotifyInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				var cur cgroups.Stats
+				if err := ctrl.GetStats(path, &cur); err != nil {
+					continue
+				}
+
+				event, ok := nextThrottleEvent(prev, cur, threshold)
+				prev = cur
+				if !ok {
+					continue
+				}
+
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,45 @@
+// +build linux
+
+package cpu
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+func statsWithThrottling(periods, throttledPeriods, throttledTime uint64) cgroups.Stats {
+	var s cgroups.Stats
+	s.CpuStats.ThrottlingData.Periods = periods
+	s.CpuStats.ThrottlingData.ThrottledPeriods = throttledPeriods
+	s.CpuStats.ThrottlingData.ThrottledTime = throttledTime
+	return s
+}
+
+func TestNextThrottleEventBelowThreshold(t *testing.T) {
+	prev := statsWithThrottling(10, 1, 1000)
+	cur := statsWithThrottling(20, 2, 1500)
+
+	if _, ok := nextThrottleEvent(prev, cur, 1000); ok {
+		t.Fatal("expected no event when delta does not exceed threshold")
+	}
+}
+
+func TestNextThrottleEventAboveThreshold(t *testing.T) {
+	prev := statsWithThrottling(10, 1, 1000)
+	cur := statsWithThrottling(25, 4, 5000)
+
+	event, ok := nextThrottleEvent(prev, cur, 1000)
+	if !ok {
+		t.Fatal("expected an event when delta exceeds threshold")
+	}
+	if event.Periods != 15 {
+		t.Errorf("Periods = %d, want 15", event.Periods)
+	}
+	if event.ThrottledPeriods != 3 {
+		t.Errorf("ThrottledPeriods = %d, want 3", event.ThrottledPeriods)
+	}
+	if event.ThrottledTime != 4000 {
+		t.Errorf("ThrottledTime = %d, want 4000", event.ThrottledTime)
+	}
+}
@@ -0,0 +1,54 @@
+// Package cpuhelpers provides conversions between Kubernetes-style
+// milli-CPU values and the CFS quota/period and cpu.shares values that the
+// cgroup cpu controller actually understands.
+package cpuhelpers
+
+const (
+	// defaultPeriod is the CFS period, in microseconds, used to derive a
+	// quota from a milli-CPU value.
+	defaultPeriod = uint64(100000)
+
+	// MinQuotaPeriod is the smallest quota, in microseconds, MilliCPUToQuota
+	// will ever return for a positive milliCPU value.
+	MinQuotaPeriod = int64(1000)
+
+	minShares = uint64(2)
+)
+
+// MilliCPUToQuota converts a milli-CPU value (1000 == one full CPU) into a
+// CFS quota and period pair suitable for cpu.cfs_quota_us/cpu.cfs_period_us
+// (or cpu.max on cgroup v2). A non-positive milliCPU means "no limit": the
+// returned quota is -1.
+func MilliCPUToQuota(milliCPU int64) (quota int64, period uint64) {
+	period = defaultPeriod
+
+	if milliCPU <= 0 {
+		return -1, period
+	}
+
+	quota = (milliCPU * int64(period)) / 1000
+
+	// ceil
+	if milliCPU*int64(period)%1000 != 0 {
+		quota++
+	}
+
+	if quota < MinQuotaPeriod {
+		quota = MinQuotaPeriod
+	}
+
+	return quota, period
+}
+
+// SharesFromMilliCPU converts a milli-CPU value into a cpu.shares value,
+// floored at 2 (the minimum cpu.shares the kernel accepts).
+func SharesFromMilliCPU(milliCPU int64) uint64 {
+	if milliCPU <= 0 {
+		return minShares
+	}
+	shares := uint64(milliCPU*1024) / 1000
+	if shares < minShares {
+		return minShares
+	}
+	return shares
+}
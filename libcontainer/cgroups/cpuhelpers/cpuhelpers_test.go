@@ -0,0 +1,42 @@
+package cpuhelpers
+
+import "testing"
+
+func TestMilliCPUToQuota(t *testing.T) {
+	cases := []struct {
+		milliCPU   int64
+		wantQuota  int64
+		wantPeriod uint64
+	}{
+		{0, -1, 100000},    // no limit
+		{-500, -1, 100000}, // no limit
+		{500, 50000, 100000},
+		{1, MinQuotaPeriod, 100000}, // floored at MinQuotaPeriod
+		{2500, 250000, 100000},
+	}
+	for _, c := range cases {
+		quota, period := MilliCPUToQuota(c.milliCPU)
+		if quota != c.wantQuota || period != c.wantPeriod {
+			t.Errorf("MilliCPUToQuota(%d) = (%d, %d), want (%d, %d)",
+				c.milliCPU, quota, period, c.wantQuota, c.wantPeriod)
+		}
+	}
+}
+
+func TestSharesFromMilliCPU(t *testing.T) {
+	cases := []struct {
+		milliCPU int64
+		want     uint64
+	}{
+		{0, minShares},
+		{-500, minShares}, // must not underflow through the uint64 cast
+		{1, minShares},
+		{1000, 1024},
+		{500, 512},
+	}
+	for _, c := range cases {
+		if got := SharesFromMilliCPU(c.milliCPU); got != c.want {
+			t.Errorf("SharesFromMilliCPU(%d) = %d, want %d", c.milliCPU, got, c.want)
+		}
+	}
+}
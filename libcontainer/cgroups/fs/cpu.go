@@ -4,11 +4,14 @@ package fs
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/cgroups/cpuhelpers"
 	"github.com/opencontainers/runc/libcontainer/configs"
 )
 
@@ -29,6 +32,7 @@ func (s *CpuGroup) Apply(d *cgroupData) error {
 	return s.ApplyDir(path, d.config, d.pid)
 }
 
+// ApplyDir creates the cgroup directory at path and joins pid to it.
 func (s *CpuGroup) ApplyDir(path string, cgroup *configs.Cgroup, pid int) error {
 	// This might happen if we have no cpu cgroup mounted.
 	// Just do nothing and don't fail.
@@ -49,6 +53,14 @@ func (s *CpuGroup) ApplyDir(path string, cgroup *configs.Cgroup, pid int) error
 	return cgroups.WriteCgroupProc(path, pid)
 }
 
+// ApplyDirWithParent is like ApplyDir, but also takes parentPath, the pod
+// (parent) cgroup this container's cpu group is nested under. parentPath is
+// neither used nor recorded here; validation of quota/period against the
+// parent happens only in SetWithParent, which takes the parent path itself.
+func (s *CpuGroup) ApplyDirWithParent(path, parentPath string, cgroup *configs.Cgroup, pid int) error {
+	return s.ApplyDir(path, cgroup, pid)
+}
+
 func (s *CpuGroup) SetRtSched(path string, cgroup *configs.Cgroup) error {
 	if cgroup.Resources.CpuRtPeriod != 0 {
 		if err := writeFile(path, "cpu.rt_period_us", strconv.FormatUint(cgroup.Resources.CpuRtPeriod, 10)); err != nil {
@@ -63,39 +75,148 @@ func (s *CpuGroup) SetRtSched(path string, cgroup *configs.Cgroup) error {
 	return nil
 }
 
+// Set implements the subsystem interface's Set(path, cgroup) signature, the
+// same one every other v1 subsystem (memory, pids, cpuset, blkio, ...)
+// implements, so the manager's generic "for _, sys := range subsystems"
+// loop can call it uniformly. It has no parent cgroup to validate
+// quota/period against; use SetWithParent for that (e.g. from a pod).
 func (s *CpuGroup) Set(path string, cgroup *configs.Cgroup) error {
-	if cgroup.Resources.CpuShares != 0 {
-		if err := writeFile(path, "cpu.shares", strconv.FormatUint(cgroup.Resources.CpuShares, 10)); err != nil {
+	return s.SetWithParent(path, "", cgroup)
+}
+
+// SetWithParent is like Set, but parentPath, when non-empty, identifies a
+// pod (parent) cgroup whose quota/period ratio the child must not exceed;
+// the final quota/period is checked against it before anything is written,
+// and the period->quota->period write sequence below still runs as in Set.
+func (s *CpuGroup) SetWithParent(path, parentPath string, cgroup *configs.Cgroup) error {
+	// CpuMilli is a convenience API: when the explicit shares/quota/period
+	// are left unset, derive them from it via the standard milli-CPU
+	// mapping instead of making every caller reimplement the conversion.
+	shares := cgroup.Resources.CpuShares
+	quota := cgroup.Resources.CpuQuota
+	period := cgroup.Resources.CpuPeriod
+	if cgroup.Resources.CpuMilli != 0 {
+		if shares == 0 {
+			shares = cpuhelpers.SharesFromMilliCPU(cgroup.Resources.CpuMilli)
+		}
+		if quota == 0 && period == 0 {
+			quota, period = cpuhelpers.MilliCPUToQuota(cgroup.Resources.CpuMilli)
+		}
+	}
+
+	if shares != 0 {
+		if err := writeFile(path, "cpu.shares", strconv.FormatUint(shares, 10)); err != nil {
 			return err
 		}
 	}
-	// The order of setting cfs_quota_us and cfs_period_us is significant, since cgroup child node
-	// should not have a higher quota/period ratio than its parent.
-	// Use period->quota-->period sequence to make sure no matter parent or child node is changed,
-	// as long as the vlaue is valid, it would be written into cgroup successfully
-	// Note: if there are three or more node in cgroup tree, sometimes changing node in the middle
-	// would not be possbile.
-	// i.e. 1000/1000 -> 1000/1000 -> 1000/1000, chaning the middle node to 10000/10000 would always fail
-	// unless there is some automic way to make cgroup changes.
-	reorder := false
-	if cgroup.Resources.CpuPeriod != 0 {
-		if err := writeFile(path, "cpu.cfs_period_us", strconv.FormatUint(cgroup.Resources.CpuPeriod, 10)); err != nil {
-			reorder = true
+	if quota != 0 || period != 0 {
+		if parentPath != "" {
+			// This only validates the final (quota, period) against the
+			// parent's ratio, for a clear error up front. It says nothing
+			// about this cgroup's own current on-disk quota/period, so the
+			// write below can still transiently violate the child-ratio
+			// check the kernel enforces on write — hence the same
+			// reorder-and-retry dance as the no-parent case.
+			if err := validateQuotaAgainstParent(parentPath, quota, period); err != nil {
+				return err
+			}
+		}
+		// The order of setting cfs_quota_us and cfs_period_us is significant, since cgroup child node
+		// should not have a higher quota/period ratio than its parent.
+		// Use period->quota-->period sequence to make sure no matter parent or child node is changed,
+		// as long as the vlaue is valid, it would be written into cgroup successfully
+		// Note: if there are three or more node in cgroup tree, sometimes changing node in the middle
+		// would not be possbile.
+		// i.e. 1000/1000 -> 1000/1000 -> 1000/1000, chaning the middle node to 10000/10000 would always fail
+		// unless there is some automic way to make cgroup changes.
+		reorder := false
+		if period != 0 {
+			if err := writeFile(path, "cpu.cfs_period_us", strconv.FormatUint(period, 10)); err != nil {
+				reorder = true
+			}
+		}
+		if quota != 0 {
+			if err := writeFile(path, "cpu.cfs_quota_us", strconv.FormatInt(quota, 10)); err != nil {
+				return err
+			}
+		}
+		if reorder {
+			if err := writeFile(path, "cpu.cfs_period_us", strconv.FormatUint(period, 10)); err != nil {
+				return err
+			}
 		}
 	}
-	if cgroup.Resources.CpuQuota != 0 {
-		if err := writeFile(path, "cpu.cfs_quota_us", strconv.FormatInt(cgroup.Resources.CpuQuota, 10)); err != nil {
+	if cgroup.Resources.CpuBurst != nil {
+		burst := *cgroup.Resources.CpuBurst
+		// quota may be 0 here because this call leaves it unchanged (e.g.
+		// a runc update that only touches burst), not because the cgroup
+		// is unlimited, so fall back to the quota already on disk rather
+		// than skipping validation.
+		effectiveQuota := quota
+		if effectiveQuota == 0 {
+			if onDiskQuota, _, err := readCpuQuotaPeriod(path); err == nil {
+				effectiveQuota = onDiskQuota
+			}
+		}
+		if effectiveQuota > 0 && burst > uint64(effectiveQuota) {
+			return fmt.Errorf("cpu burst (%d) must not exceed cpu quota (%d)", burst, effectiveQuota)
+		}
+		if err := writeFile(path, "cpu.cfs_burst_us", strconv.FormatUint(burst, 10)); err != nil {
 			return err
 		}
 	}
-	if reorder {
-		if err := writeFile(path, "cpu.cfs_period_us", strconv.FormatUint(cgroup.Resources.CpuPeriod, 10)); err != nil {
+	if cgroup.Resources.CpuIdle != nil {
+		if err := writeFile(path, "cpu.idle", strconv.FormatInt(*cgroup.Resources.CpuIdle, 10)); err != nil {
 			return err
 		}
 	}
 	return s.SetRtSched(path, cgroup)
 }
 
+// validateQuotaAgainstParent checks that the child's quota/period ratio
+// (once period and/or quota default to the parent's current values) does
+// not exceed the parent cgroup's own ratio, returning a clear error instead
+// of letting the kernel reject the write.
+func validateQuotaAgainstParent(parentPath string, quota int64, period uint64) error {
+	parentQuota, parentPeriod, err := readCpuQuotaPeriod(parentPath)
+	if err != nil || parentQuota <= 0 {
+		// No (or unlimited) quota on the parent: nothing to validate against.
+		return nil
+	}
+	if quota <= 0 {
+		quota = parentQuota
+	}
+	if period == 0 {
+		period = parentPeriod
+	}
+	// cross-multiply to avoid floating point: quota/period <= parentQuota/parentPeriod
+	if quota*int64(parentPeriod) > parentQuota*int64(period) {
+		return fmt.Errorf("cpu quota/period ratio (%d/%d) exceeds parent cgroup %s ratio (%d/%d)",
+			quota, period, parentPath, parentQuota, parentPeriod)
+	}
+	return nil
+}
+
+func readCpuQuotaPeriod(path string) (quota int64, period uint64, err error) {
+	quotaStr, err := readFile(path, "cpu.cfs_quota_us")
+	if err != nil {
+		return 0, 0, err
+	}
+	quota, err = strconv.ParseInt(strings.TrimSpace(quotaStr), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	periodStr, err := readFile(path, "cpu.cfs_period_us")
+	if err != nil {
+		return 0, 0, err
+	}
+	period, err = strconv.ParseUint(strings.TrimSpace(periodStr), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return quota, period, nil
+}
+
 func (s *CpuGroup) Remove(d *cgroupData) error {
 	return removePath(d.path("cpu"))
 }
@@ -0,0 +1,60 @@
+// +build linux
+
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func writeParentQuotaPeriod(t *testing.T, dir string, quota int64, period uint64) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "cpu.cfs_quota_us"), []byte(fmt.Sprintf("%d", quota)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "cpu.cfs_period_us"), []byte(fmt.Sprintf("%d", period)), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateQuotaAgainstParent(t *testing.T) {
+	parent := t.TempDir()
+	writeParentQuotaPeriod(t, parent, 50000, 100000) // parent ratio 0.5
+
+	// Child within the parent's ratio is fine.
+	if err := validateQuotaAgainstParent(parent, 25000, 100000); err != nil {
+		t.Fatalf("unexpected error for child within parent ratio: %v", err)
+	}
+
+	// Child exceeding the parent's ratio is rejected with a clear error.
+	if err := validateQuotaAgainstParent(parent, 90000, 100000); err == nil {
+		t.Fatal("expected an error for a child ratio exceeding the parent")
+	}
+
+	// A parent with no quota (unlimited) imposes no constraint.
+	unlimited := t.TempDir()
+	writeParentQuotaPeriod(t, unlimited, -1, 100000)
+	if err := validateQuotaAgainstParent(unlimited, 90000, 100000); err != nil {
+		t.Fatalf("unexpected error against an unlimited parent: %v", err)
+	}
+}
+
+// TestSetBurstValidatesAgainstOnDiskQuotaWhenUnchanged covers a runc update
+// that only sets burst, leaving quota at 0 for this call because it is
+// unchanged from a previous Set: validation must fall back to the quota
+// already on disk instead of skipping the check entirely.
+func TestSetBurstValidatesAgainstOnDiskQuotaWhenUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	writeParentQuotaPeriod(t, dir, 10000, 100000)
+
+	burst := uint64(50000) // exceeds the on-disk quota above
+	s := &CpuGroup{}
+	cgroup := &configs.Cgroup{Resources: &configs.Resources{CpuBurst: &burst}}
+	if err := s.Set(dir, cgroup); err == nil {
+		t.Fatal("expected an error when burst exceeds the on-disk quota")
+	}
+}
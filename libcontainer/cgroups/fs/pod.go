@@ -0,0 +1,93 @@
+// +build linux
+
+package fs
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// PodManager implements cgroups.PodManager on top of the v1 (fs) cgroup
+// subsystems. Only the cpu controller is wired up today; as the other
+// subsystems in this package grow pod support they should be added here
+// the same way CpuGroup is.
+type PodManager struct {
+	path     string
+	cpu      CpuGroup
+	cgroup   *configs.Cgroup
+	children []string
+}
+
+// NewPodManager returns a PodManager that will create its parent cgroup at
+// cgroupPath once Apply is called.
+func NewPodManager(cgroup *configs.Cgroup) *PodManager {
+	return &PodManager{cgroup: cgroup}
+}
+
+func (p *PodManager) Apply(cgroupPath string) error {
+	p.path = cgroupPath
+	// pid 0: the parent cgroup holds no process of its own, it only
+	// groups the container cgroups nested under it.
+	return p.cpu.ApplyDirWithParent(p.path, "", p.cgroup, 0)
+}
+
+func (p *PodManager) Set(resources *configs.Resources) error {
+	if p.path == "" {
+		return fmt.Errorf("pod cgroup: Apply must be called before Set")
+	}
+	p.cgroup.Resources = resources
+	return p.cpu.SetWithParent(p.path, "", p.cgroup)
+}
+
+// AddContainer registers containerCgroupPath as a child of the pod. The
+// container's own CpuGroup.SetWithParent calls should pass p.path as the
+// parentPath so their quota/period is validated against this pod's ratio.
+func (p *PodManager) AddContainer(containerCgroupPath string) error {
+	for _, c := range p.children {
+		if c == containerCgroupPath {
+			return nil
+		}
+	}
+	p.children = append(p.children, containerCgroupPath)
+	return nil
+}
+
+// RemoveContainer deregisters containerCgroupPath from the pod, e.g. once
+// the container itself has been removed. Destroy refuses to run while any
+// container is still registered, so this must be called for every
+// AddContainer before the pod can be torn down.
+func (p *PodManager) RemoveContainer(containerCgroupPath string) error {
+	for i, c := range p.children {
+		if c == containerCgroupPath {
+			p.children = append(p.children[:i], p.children[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("pod cgroup %s: container %s is not attached", p.path, containerCgroupPath)
+}
+
+func (p *PodManager) GetStats() (*cgroups.Stats, error) {
+	stats := cgroups.NewStats()
+	if err := p.cpu.GetStats(p.path, stats); err != nil {
+		return nil, err
+	}
+	for _, child := range p.children {
+		childStats := cgroups.NewStats()
+		if err := p.cpu.GetStats(child, childStats); err != nil {
+			return nil, err
+		}
+		stats.CpuStats.ThrottlingData.Periods += childStats.CpuStats.ThrottlingData.Periods
+		stats.CpuStats.ThrottlingData.ThrottledPeriods += childStats.CpuStats.ThrottlingData.ThrottledPeriods
+		stats.CpuStats.ThrottlingData.ThrottledTime += childStats.CpuStats.ThrottlingData.ThrottledTime
+	}
+	return stats, nil
+}
+
+func (p *PodManager) Destroy() error {
+	if len(p.children) != 0 {
+		return fmt.Errorf("pod cgroup %s: %d container(s) still attached", p.path, len(p.children))
+	}
+	return removePath(p.path, nil)
+}
@@ -0,0 +1,54 @@
+// +build linux
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestPodManagerAddRemoveContainer(t *testing.T) {
+	p := NewPodManager(&configs.Cgroup{Resources: &configs.Resources{}})
+
+	if err := p.AddContainer("/sys/fs/cgroup/cpu/pod/c1"); err != nil {
+		t.Fatalf("AddContainer: %v", err)
+	}
+	// Adding the same container twice should be a no-op, not a duplicate.
+	if err := p.AddContainer("/sys/fs/cgroup/cpu/pod/c1"); err != nil {
+		t.Fatalf("AddContainer (dup): %v", err)
+	}
+	if len(p.children) != 1 {
+		t.Fatalf("children = %v, want 1 entry", p.children)
+	}
+
+	if err := p.RemoveContainer("/sys/fs/cgroup/cpu/pod/c1"); err != nil {
+		t.Fatalf("RemoveContainer: %v", err)
+	}
+	if len(p.children) != 0 {
+		t.Fatalf("children = %v, want empty after RemoveContainer", p.children)
+	}
+
+	if err := p.RemoveContainer("/sys/fs/cgroup/cpu/pod/missing"); err == nil {
+		t.Fatal("RemoveContainer of an unregistered container should error")
+	}
+}
+
+func TestPodManagerDestroyRefusesWithAttachedContainers(t *testing.T) {
+	p := NewPodManager(&configs.Cgroup{Resources: &configs.Resources{}})
+	p.path = t.TempDir()
+
+	if err := p.AddContainer("/sys/fs/cgroup/cpu/pod/c1"); err != nil {
+		t.Fatalf("AddContainer: %v", err)
+	}
+	if err := p.Destroy(); err == nil {
+		t.Fatal("Destroy should refuse to run while a container is still attached")
+	}
+
+	if err := p.RemoveContainer("/sys/fs/cgroup/cpu/pod/c1"); err != nil {
+		t.Fatalf("RemoveContainer: %v", err)
+	}
+	if err := p.Destroy(); err != nil {
+		t.Fatalf("Destroy: %v", err)
+	}
+}
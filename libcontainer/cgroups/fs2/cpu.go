@@ -0,0 +1,131 @@
+// +build linux
+
+package fs2
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// CpuGroup is the cgroup v2 (unified hierarchy) counterpart of
+// fs.CpuGroup. It speaks cpu.weight/cpu.max/cpu.stat instead of the
+// v1 cpu.shares/cpu.cfs_quota_us/cpu.cfs_period_us/cpu.stat files.
+type CpuGroup struct {
+}
+
+func (s *CpuGroup) Name() string {
+	return "cpu"
+}
+
+func (s *CpuGroup) Apply(path string, cgroup *configs.Cgroup, pid int) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+	return cgroups.WriteCgroupProc(path, pid)
+}
+
+func (s *CpuGroup) Set(path string, cgroup *configs.Cgroup) error {
+	if cgroup.Resources.CpuShares != 0 {
+		weight := convertCPUSharesToCgroupV2Value(cgroup.Resources.CpuShares)
+		if err := writeFile(path, "cpu.weight", strconv.FormatUint(weight, 10)); err != nil {
+			return err
+		}
+	}
+	if cgroup.Resources.CpuQuota != 0 || cgroup.Resources.CpuPeriod != 0 {
+		quota := "max"
+		if cgroup.Resources.CpuQuota > 0 {
+			quota = strconv.FormatInt(cgroup.Resources.CpuQuota, 10)
+		}
+		period := cgroup.Resources.CpuPeriod
+		if period == 0 {
+			period = 100000
+		}
+		if err := writeFile(path, "cpu.max", fmt.Sprintf("%s %d", quota, period)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertCPUSharesToCgroupV2Value converts the v1 cpu.shares value (2-262144)
+// into the v2 cpu.weight value (1-10000), using the mapping documented in the
+// kernel's cgroup-v2.rst.
+func convertCPUSharesToCgroupV2Value(shares uint64) uint64 {
+	if shares == 0 {
+		return 100
+	}
+	// Clamp into the valid v1 range first: shares-2 below underflows for
+	// shares < 2 (wrapping to ~2^64 and producing the *maximum* weight,
+	// the opposite of what a too-low shares value should mean).
+	if shares < 2 {
+		shares = 2
+	}
+	if shares > 262144 {
+		shares = 262144
+	}
+	weight := 1 + ((shares-2)*9999)/262142
+	if weight < 1 {
+		weight = 1
+	}
+	if weight > 10000 {
+		weight = 10000
+	}
+	return weight
+}
+
+func (s *CpuGroup) GetStats(path string, stats *cgroups.Stats) error {
+	f, err := os.Open(filepath.Join(path, "cpu.stat"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "nr_periods":
+			stats.CpuStats.ThrottlingData.Periods = v
+		case "nr_throttled":
+			stats.CpuStats.ThrottlingData.ThrottledPeriods = v
+		case "throttled_usec":
+			// v2 reports microseconds, the rest of runc's stats assume
+			// nanoseconds throughout.
+			stats.CpuStats.ThrottlingData.ThrottledTime = v * 1000
+		case "usage_usec":
+			stats.CpuStats.CpuUsage.TotalUsage = v * 1000
+		case "user_usec":
+			stats.CpuStats.CpuUsage.UsageInUsermode = v * 1000
+		case "system_usec":
+			stats.CpuStats.CpuUsage.UsageInKernelmode = v * 1000
+		}
+	}
+	return nil
+}
+
+func writeFile(dir, file, data string) error {
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(data), 0700); err != nil {
+		return fmt.Errorf("failed to write %q to %q: %w", data, filepath.Join(dir, file), err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// +build linux
+
+package fs2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+func TestConvertCPUSharesToCgroupV2Value(t *testing.T) {
+	cases := []struct {
+		shares uint64
+		want   uint64
+	}{
+		{0, 100},
+		{1, 1},       // below the valid v1 range: clamp up to 2, not underflow to ~max
+		{2, 1},
+		{262144, 10000},
+		{1 << 20, 10000}, // above the valid v1 range: clamp down, not overflow
+		{1024, 39},
+	}
+	for _, c := range cases {
+		if got := convertCPUSharesToCgroupV2Value(c.shares); got != c.want {
+			t.Errorf("convertCPUSharesToCgroupV2Value(%d) = %d, want %d", c.shares, got, c.want)
+		}
+	}
+}
+
+func TestCpuGroupGetStats(t *testing.T) {
+	dir := t.TempDir()
+	content := "usage_usec 1000\nuser_usec 600\nsystem_usec 400\nnr_periods 10\nnr_throttled 2\nthrottled_usec 500\n"
+	if err := os.WriteFile(filepath.Join(dir, "cpu.stat"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &CpuGroup{}
+	stats := cgroups.NewStats()
+	if err := s.GetStats(dir, stats); err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+
+	if stats.CpuStats.ThrottlingData.Periods != 10 {
+		t.Errorf("Periods = %d, want 10", stats.CpuStats.ThrottlingData.Periods)
+	}
+	if stats.CpuStats.ThrottlingData.ThrottledPeriods != 2 {
+		t.Errorf("ThrottledPeriods = %d, want 2", stats.CpuStats.ThrottlingData.ThrottledPeriods)
+	}
+	// throttled_usec is reported in microseconds; ThrottledTime is nanoseconds.
+	if stats.CpuStats.ThrottlingData.ThrottledTime != 500000 {
+		t.Errorf("ThrottledTime = %d, want 500000", stats.CpuStats.ThrottlingData.ThrottledTime)
+	}
+	if stats.CpuStats.CpuUsage.TotalUsage != 1000000 {
+		t.Errorf("TotalUsage = %d, want 1000000", stats.CpuStats.CpuUsage.TotalUsage)
+	}
+}
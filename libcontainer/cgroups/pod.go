@@ -0,0 +1,37 @@
+package cgroups
+
+import "github.com/opencontainers/runc/libcontainer/configs"
+
+// PodManager manages a parent ("pod") cgroup that one or more container
+// cgroups are nested under. Resource limits set through Set are applied on
+// the parent directory itself, constraining (and, where the kernel
+// supports it, being inherited by) every container added via AddContainer.
+//
+// Unlike Manager, which owns a single cgroup tree for one container,
+// PodManager owns the parent and only tracks the paths of the containers
+// nested under it, so that GetStats can aggregate across them.
+type PodManager interface {
+	// Apply creates the parent cgroup directory at cgroupPath.
+	Apply(cgroupPath string) error
+
+	// Set applies resource limits to the parent cgroup.
+	Set(resources *configs.Resources) error
+
+	// AddContainer registers a container cgroup nested under the pod so
+	// its stats are included in GetStats. The container is expected to
+	// have already applied its own cgroup under containerCgroupPath.
+	AddContainer(containerCgroupPath string) error
+
+	// RemoveContainer deregisters a container cgroup previously added via
+	// AddContainer, e.g. once the container itself has been removed. This
+	// must be called for every AddContainer before Destroy will succeed.
+	RemoveContainer(containerCgroupPath string) error
+
+	// GetStats returns stats for the parent cgroup aggregated with those
+	// of every container added via AddContainer.
+	GetStats() (*Stats, error)
+
+	// Destroy removes the parent cgroup. It fails if any container added
+	// via AddContainer has not been removed from the pod first.
+	Destroy() error
+}
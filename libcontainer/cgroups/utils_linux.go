@@ -0,0 +1,31 @@
+// +build linux
+
+package cgroups
+
+import (
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+const unifiedMountpoint = "/sys/fs/cgroup"
+
+var (
+	isUnifiedOnce sync.Once
+	isUnified     bool
+)
+
+// IsCgroup2UnifiedMode returns whether we are running in cgroup v2 unified
+// mode, i.e. /sys/fs/cgroup is a cgroup2 mount rather than a tmpfs hosting
+// the individual v1 subsystem mounts.
+func IsCgroup2UnifiedMode() bool {
+	isUnifiedOnce.Do(func() {
+		var st unix.Statfs_t
+		if err := unix.Statfs(unifiedMountpoint, &st); err != nil {
+			isUnified = false
+			return
+		}
+		isUnified = st.Type == unix.CGROUP2_SUPER_MAGIC
+	})
+	return isUnified
+}
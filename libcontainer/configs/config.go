@@ -0,0 +1,48 @@
+package configs
+
+// Cgroup holds the cgroup configuration for a container, as well as the
+// resource limits to apply to it.
+type Cgroup struct {
+	// Path is the cgroup path, relative to the subsystem mount point (or,
+	// for a pod's parent cgroup, an absolute path).
+	Path string
+
+	// Resources contains the resource limits to apply.
+	Resources *Resources
+}
+
+// Resources holds the cgroup resource limits applied by the subsystems in
+// this tree. Only the knobs the cpu controller understands are defined
+// here today; as other controllers gain support their fields belong here
+// too.
+type Resources struct {
+	// CpuShares is the relative share of CPU time. Written to cpu.shares
+	// on v1, translated to cpu.weight on v2.
+	CpuShares uint64
+
+	// CpuQuota is the hard cap on CPU time allowed per CpuPeriod, in
+	// microseconds. A value <= 0 means "no limit".
+	CpuQuota int64
+
+	// CpuPeriod is the length of a CFS scheduling period, in microseconds.
+	CpuPeriod uint64
+
+	// CpuRtRuntime and CpuRtPeriod configure realtime scheduling
+	// bandwidth (cpu.rt_runtime_us/cpu.rt_period_us).
+	CpuRtRuntime int64
+	CpuRtPeriod  uint64
+
+	// CpuBurst is the CFS burst budget (cpu.cfs_burst_us), in
+	// microseconds, accumulated while the cgroup underruns its quota.
+	// Must be <= CpuQuota.
+	CpuBurst *uint64
+
+	// CpuIdle marks the cgroup as SCHED_IDLE (cpu.idle) when set to 1.
+	CpuIdle *int64
+
+	// CpuMilli is a convenience alternative to CpuShares/CpuQuota/CpuPeriod,
+	// expressed in milli-CPUs (1000 == one full CPU). The v1 fs.CpuGroup.Set
+	// derives the explicit values from it via the cpuhelpers package when
+	// they are left unset; the v2 fs2.CpuGroup.Set does not read it.
+	CpuMilli int64
+}
@@ -0,0 +1,53 @@
+// Package specconv translates OCI runtime-spec configuration into the
+// libcontainer/configs representation the cgroup subsystems operate on.
+package specconv
+
+import (
+	"github.com/opencontainers/runc/libcontainer/configs"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// setCPUResources copies the OCI Linux CPU resource knobs from spec into r,
+// leaving fields unset in spec untouched in r.
+func setCPUResources(spec *specs.LinuxCPU, r *configs.Resources) {
+	if spec == nil {
+		return
+	}
+	if spec.Shares != nil {
+		r.CpuShares = *spec.Shares
+	}
+	if spec.Quota != nil {
+		r.CpuQuota = *spec.Quota
+	}
+	if spec.Period != nil {
+		r.CpuPeriod = *spec.Period
+	}
+	if spec.RealtimeRuntime != nil {
+		r.CpuRtRuntime = *spec.RealtimeRuntime
+	}
+	if spec.RealtimePeriod != nil {
+		r.CpuRtPeriod = *spec.RealtimePeriod
+	}
+	if spec.Burst != nil {
+		burst := *spec.Burst
+		r.CpuBurst = &burst
+	}
+	if spec.Idle != nil {
+		idle := *spec.Idle
+		r.CpuIdle = &idle
+	}
+}
+
+// CreateCgroupConfig fills in the cgroup resource limits of config from the
+// OCI spec, so that CpuGroup.Set (and, via runc update, user-requested
+// changes) actually have a path from the container config / CLI down to
+// the cgroup knobs.
+func CreateCgroupConfig(spec *specs.Spec, config *configs.Cgroup) {
+	if config.Resources == nil {
+		config.Resources = &configs.Resources{}
+	}
+	if spec == nil || spec.Linux == nil || spec.Linux.Resources == nil {
+		return
+	}
+	setCPUResources(spec.Linux.Resources.CPU, config.Resources)
+}
@@ -0,0 +1,49 @@
+package specconv
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func u64p(v uint64) *uint64 { return &v }
+func i64p(v int64) *int64   { return &v }
+
+func TestCreateCgroupConfigSurfacesBurstAndIdle(t *testing.T) {
+	spec := &specs.Spec{
+		Linux: &specs.Linux{
+			Resources: &specs.LinuxResources{
+				CPU: &specs.LinuxCPU{
+					Quota: i64p(50000),
+					Burst: u64p(20000),
+					Idle:  i64p(1),
+				},
+			},
+		},
+	}
+	config := &configs.Cgroup{}
+
+	CreateCgroupConfig(spec, config)
+
+	if config.Resources.CpuQuota != 50000 {
+		t.Errorf("CpuQuota = %d, want 50000", config.Resources.CpuQuota)
+	}
+	if config.Resources.CpuBurst == nil || *config.Resources.CpuBurst != 20000 {
+		t.Errorf("CpuBurst = %v, want 20000", config.Resources.CpuBurst)
+	}
+	if config.Resources.CpuIdle == nil || *config.Resources.CpuIdle != 1 {
+		t.Errorf("CpuIdle = %v, want 1", config.Resources.CpuIdle)
+	}
+
+	// Burst/Idle must be copied, not aliased: mutating the spec afterwards
+	// (or reusing it for another container) must not change config too.
+	*spec.Linux.Resources.CPU.Burst = 99999
+	*spec.Linux.Resources.CPU.Idle = 0
+	if *config.Resources.CpuBurst != 20000 {
+		t.Errorf("CpuBurst changed after mutating spec: got %d, want 20000", *config.Resources.CpuBurst)
+	}
+	if *config.Resources.CpuIdle != 1 {
+		t.Errorf("CpuIdle changed after mutating spec: got %d, want 1", *config.Resources.CpuIdle)
+	}
+}
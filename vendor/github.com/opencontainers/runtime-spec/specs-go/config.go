@@ -0,0 +1,41 @@
+// Package specs-go contains the subset of the OCI runtime-spec config
+// types that this tree's specconv package translates into libcontainer
+// configuration. It is trimmed to what's actually used here rather than
+// a full vendor import.
+package specs
+
+// Spec is the base configuration for the container.
+type Spec struct {
+	Linux *Linux `json:"linux,omitempty"`
+}
+
+// Linux contains platform-specific configuration for Linux based
+// containers.
+type Linux struct {
+	Resources *LinuxResources `json:"resources,omitempty"`
+}
+
+// LinuxResources has container runtime resource constraints.
+type LinuxResources struct {
+	CPU *LinuxCPU `json:"cpu,omitempty"`
+}
+
+// LinuxCPU for Linux cgroup 'cpu' resource management.
+type LinuxCPU struct {
+	// Shares (relative weight (ratio) vs. other cgroups with cpu shares).
+	Shares *uint64 `json:"shares,omitempty"`
+	// Quota allocated for this cgroup (in microseconds).
+	Quota *int64 `json:"quota,omitempty"`
+	// Burst is the hard cap on burst (in microseconds).
+	Burst *uint64 `json:"burst,omitempty"`
+	// Period is the CFS period (in microseconds).
+	Period *uint64 `json:"period,omitempty"`
+	// RealtimeRuntime is the hard cap for realtime scheduling (in
+	// microseconds).
+	RealtimeRuntime *int64 `json:"realtimeRuntime,omitempty"`
+	// RealtimePeriod is the CPU period for realtime scheduling (in
+	// microseconds).
+	RealtimePeriod *uint64 `json:"realtimePeriod,omitempty"`
+	// Idle marks the cgroup as SCHED_IDLE (0 or 1).
+	Idle *int64 `json:"idle,omitempty"`
+}